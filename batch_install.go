@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// aggregateRefreshInterval controls how often the overall progress bar is
+// recomputed from the per-row bars while a batch install is running.
+const aggregateRefreshInterval = 300 * time.Millisecond
+
+// installRow is one line of the batch installation list: a package name,
+// its status text and its own progress bar, driven by its own DBIN_PB_FIFO.
+type installRow struct {
+	program     string
+	binaryName  string
+	statusLabel *widget.Label
+	progress    *ProgressIndicator
+	controls    *controlButtons
+}
+
+func newInstallRow(ui *UI, program, binaryName string) *installRow {
+	statusLabel := widget.NewLabel("Queued...")
+
+	return &installRow{
+		program:     program,
+		binaryName:  binaryName,
+		statusLabel: statusLabel,
+		progress:    NewProgressIndicator(),
+		controls:    ui.buildControlButtons(statusLabel),
+	}
+}
+
+func (r *installRow) Content() fyne.CanvasObject {
+	nameLabel := widget.NewLabel(r.binaryName)
+	nameLabel.TextStyle.Bold = true
+
+	return container.NewVBox(
+		container.NewBorder(nil, nil, nameLabel, nil, r.statusLabel),
+		r.progress.CanvasObject(),
+		r.controls.row,
+	)
+}
+
+// buildBatchInstallationContent renders one installRow per requested
+// package in a scrollable list, plus an aggregate progress bar summarizing
+// all of them.
+func (ui *UI) buildBatchInstallationContent() (fyne.CanvasObject, []*installRow, *widget.ProgressBar) {
+	header := ui.createHeader(fmt.Sprintf("Installing %d packages", len(ui.programs)))
+
+	rows := make([]*installRow, 0, len(ui.programs))
+	rowObjects := make([]fyne.CanvasObject, 0, len(ui.programs))
+	for _, program := range ui.programs {
+		info := ui.infos[program]
+		row := newInstallRow(ui, program, FormatBinaryID(info.Name, info.PkgId))
+		rows = append(rows, row)
+		rowObjects = append(rowObjects, row.Content(), widget.NewSeparator())
+	}
+
+	rowList := container.NewScroll(container.NewVBox(rowObjects...))
+	rowList.SetMinSize(fyne.NewSize(windowWidth-2*paddingSize, 240))
+
+	aggregateLabel := widget.NewLabel("Overall progress")
+	aggregateBar := widget.NewProgressBar()
+	aggregateBar.SetValue(0)
+
+	content := container.NewVBox(
+		header,
+		widget.NewSeparator(),
+		rowList,
+		widget.NewSeparator(),
+		aggregateLabel,
+		aggregateBar,
+	)
+
+	return content, rows, aggregateBar
+}
+
+// StartBatchInstallation installs every requested package concurrently,
+// one `dbin install` invocation per package, and calls onDone once all of
+// them have finished.
+func (ui *UI) StartBatchInstallation(onDone func()) {
+	content, rows, aggregateBar := ui.buildBatchInstallationContent()
+	ui.installationContent = content
+
+	done := make(chan struct{})
+
+	go func() {
+		ticker := time.NewTicker(aggregateRefreshInterval)
+		defer ticker.Stop()
+
+		updateAggregate := func() {
+			var total float64
+			for _, row := range rows {
+				total += row.progress.Value()
+			}
+			aggregateBar.SetValue(total / float64(len(rows)))
+		}
+
+		for {
+			select {
+			case <-ticker.C:
+				updateAggregate()
+			case <-done:
+				updateAggregate()
+				return
+			}
+		}
+	}()
+
+	go func() {
+		var wg sync.WaitGroup
+		for _, row := range rows {
+			wg.Add(1)
+			go func(row *installRow) {
+				defer wg.Done()
+				ui.RunInstallation(row.program, row.binaryName, row.progress, row.statusLabel, row.controls.attach, func() {})
+			}(row)
+		}
+
+		wg.Wait()
+		close(done)
+		onDone()
+	}()
+}
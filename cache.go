@@ -0,0 +1,373 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultCacheByteBudget is how much disk space the resource cache is
+// allowed to use before older entries are evicted on write. Configurable
+// via DBIN_ASK_CACHE_BYTES.
+const defaultCacheByteBudget = 256 * 1024 * 1024
+
+// Resource records one cached download: where it came from, where it
+// lives on disk, and enough metadata to make a conditional re-fetch and
+// LRU eviction possible.
+type Resource struct {
+	Type       string
+	URL        string
+	Path       string
+	ETag       string
+	SHA256     string
+	LastAccess time.Time
+}
+
+// resourceSidecar is the on-disk JSON metadata stored next to each cached
+// blob, keyed by SHA-256 of the source URL.
+type resourceSidecar struct {
+	SHA256       string    `json:"sha256"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	MIME         string    `json:"mime,omitempty"`
+	Size         int64     `json:"size"`
+	FetchedAt    time.Time `json:"fetched_at"`
+}
+
+// ResourceManager fetches icons, screenshots and license text into a
+// persistent, content-addressed on-disk cache under $XDG_CACHE_HOME/dbin-ask/,
+// so repeated runs against the same program don't redownload its assets.
+type ResourceManager struct {
+	cacheDir   string
+	byteBudget int64
+
+	mu        sync.Mutex
+	resources []Resource
+	pending   map[string]string
+}
+
+// NewResourceManager opens (creating if needed) the shared on-disk resource
+// cache. programID is accepted for API compatibility with callers that
+// still scope a ResourceManager to one program, but the cache itself is
+// shared across all programs since it's keyed by URL.
+func NewResourceManager(programID string) (*ResourceManager, error) {
+	cacheDir, err := resourceCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(cacheDir, 0750); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	budget := int64(defaultCacheByteBudget)
+	if raw := os.Getenv("DBIN_ASK_CACHE_BYTES"); raw != "" {
+		var parsed int64
+		if _, err := fmt.Sscanf(raw, "%d", &parsed); err == nil && parsed > 0 {
+			budget = parsed
+		}
+	}
+
+	return &ResourceManager{
+		cacheDir:   cacheDir,
+		byteBudget: budget,
+		pending:    make(map[string]string),
+	}, nil
+}
+
+func resourceCacheDir() (string, error) {
+	if base := os.Getenv("XDG_CACHE_HOME"); base != "" {
+		return filepath.Join(base, "dbin-ask"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve home directory: %w", err)
+	}
+	return filepath.Join(home, ".cache", "dbin-ask"), nil
+}
+
+func cacheKey(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return hex.EncodeToString(sum[:])
+}
+
+func (rm *ResourceManager) blobPath(key string) string {
+	return filepath.Join(rm.cacheDir, key+".blob")
+}
+
+func (rm *ResourceManager) sidecarPath(key string) string {
+	return filepath.Join(rm.cacheDir, key+".json")
+}
+
+func (rm *ResourceManager) readSidecar(key string) (*resourceSidecar, error) {
+	data, err := os.ReadFile(rm.sidecarPath(key))
+	if err != nil {
+		return nil, err
+	}
+	var sc resourceSidecar
+	if err := json.Unmarshal(data, &sc); err != nil {
+		return nil, err
+	}
+	return &sc, nil
+}
+
+func (rm *ResourceManager) writeSidecar(key string, sc resourceSidecar) error {
+	data, err := json.Marshal(sc)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(rm.sidecarPath(key), data, 0640)
+}
+
+// Cleanup is a no-op: the cache is persistent by design and survives
+// across runs. Use DownloadResource's checksum verification and the LRU
+// eviction to keep it bounded and correct instead of wiping it on exit.
+func (rm *ResourceManager) Cleanup() {}
+
+// DownloadResource fetches url into the persistent cache, reusing a valid
+// cached copy when possible via conditional GET, and returns the local
+// path to the blob. It checks no expected digest; downloadResourceChecked
+// does.
+func (rm *ResourceManager) DownloadResource(url, resourceType string) (string, error) {
+	return rm.downloadResourceChecked(url, resourceType, "")
+}
+
+// downloadResourceChecked is DownloadResource with an optional expected
+// SHA-256 digest, used when binaryEntry exposes a checksum for the asset.
+// expectedSHA256, if non-empty, must match the downloaded content's digest
+// or the fetch is rejected and the stale blob is left untouched.
+func (rm *ResourceManager) downloadResourceChecked(url, resourceType, expectedSHA256 string) (string, error) {
+	key := cacheKey(url)
+	blobPath := rm.blobPath(key)
+	sidecar, haveCached := rm.existingEntry(key, blobPath)
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("error building request for %s: %w", resourceType, err)
+	}
+	if haveCached {
+		if sidecar.ETag != "" {
+			req.Header.Set("If-None-Match", sidecar.ETag)
+		}
+		if sidecar.LastModified != "" {
+			req.Header.Set("If-Modified-Since", sidecar.LastModified)
+		}
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		if haveCached {
+			return rm.touchCached(key, blobPath, resourceType, url)
+		}
+		return "", fmt.Errorf("error downloading %s: %w", resourceType, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && haveCached {
+		return rm.touchCached(key, blobPath, resourceType, url)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		if haveCached {
+			return rm.touchCached(key, blobPath, resourceType, url)
+		}
+		return "", fmt.Errorf("error downloading %s: status %d", resourceType, resp.StatusCode)
+	}
+
+	tmpPath := blobPath + ".tmp"
+	tmpFile, err := os.Create(tmpPath)
+	if err != nil {
+		return "", fmt.Errorf("error creating cache file: %w", err)
+	}
+
+	rm.markPending(key, tmpPath)
+	defer rm.clearPending(key)
+
+	hasher := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(tmpFile, hasher), resp.Body); err != nil {
+		tmpFile.Close()
+		os.Remove(tmpPath)
+		return "", fmt.Errorf("error writing %s: %w", resourceType, err)
+	}
+	tmpFile.Close()
+
+	digest := hex.EncodeToString(hasher.Sum(nil))
+	if expectedSHA256 != "" && !strings.EqualFold(digest, expectedSHA256) {
+		os.Remove(tmpPath)
+		return "", fmt.Errorf("checksum mismatch for %s: expected %s, got %s", resourceType, expectedSHA256, digest)
+	}
+
+	info, err := os.Stat(tmpPath)
+	if err != nil {
+		os.Remove(tmpPath)
+		return "", fmt.Errorf("error stating cache file: %w", err)
+	}
+	if err := os.Rename(tmpPath, blobPath); err != nil {
+		os.Remove(tmpPath)
+		return "", fmt.Errorf("error finalizing cache file: %w", err)
+	}
+
+	sc := resourceSidecar{
+		SHA256:       digest,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		MIME:         resp.Header.Get("Content-Type"),
+		Size:         info.Size(),
+		FetchedAt:    time.Now(),
+	}
+	if err := rm.writeSidecar(key, sc); err != nil {
+		return "", fmt.Errorf("error writing cache metadata: %w", err)
+	}
+
+	rm.recordAccess(Resource{Type: resourceType, URL: url, Path: blobPath, ETag: sc.ETag, SHA256: digest, LastAccess: sc.FetchedAt})
+	rm.evictToBudget()
+
+	return blobPath, nil
+}
+
+// existingEntry reports whether a verified cache entry for key already
+// exists on disk.
+func (rm *ResourceManager) existingEntry(key, blobPath string) (*resourceSidecar, bool) {
+	sidecar, err := rm.readSidecar(key)
+	if err != nil {
+		return nil, false
+	}
+	data, err := os.ReadFile(blobPath)
+	if err != nil {
+		return nil, false
+	}
+	sum := sha256.Sum256(data)
+	if !strings.EqualFold(hex.EncodeToString(sum[:]), sidecar.SHA256) {
+		return nil, false
+	}
+	return sidecar, true
+}
+
+// touchCached is used for a 304 Not Modified response, a failed refresh
+// with a usable cached copy, or a non-200 refresh that still has a valid
+// local copy: it refreshes LastAccess and returns the existing blob.
+func (rm *ResourceManager) touchCached(key, blobPath, resourceType, url string) (string, error) {
+	sidecar, err := rm.readSidecar(key)
+	if err != nil {
+		return "", fmt.Errorf("error reading cache metadata for %s: %w", resourceType, err)
+	}
+	now := time.Now()
+	os.Chtimes(blobPath, now, now)
+	rm.recordAccess(Resource{Type: resourceType, URL: url, Path: blobPath, ETag: sidecar.ETag, SHA256: sidecar.SHA256, LastAccess: now})
+	return blobPath, nil
+}
+
+// markPending records that key's download is in flight at tmpPath, so a
+// concurrent AbortURLs call can find and remove it.
+func (rm *ResourceManager) markPending(key, tmpPath string) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	rm.pending[key] = tmpPath
+}
+
+func (rm *ResourceManager) clearPending(key string) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	delete(rm.pending, key)
+}
+
+// AbortURLs removes the partially-downloaded .tmp blob for any of urls that
+// is still in flight. Called when an installation covering those URLs (its
+// icon, its screenshots) is cancelled, so a cancelled row doesn't leave an
+// orphaned temp download behind.
+func (rm *ResourceManager) AbortURLs(urls []string) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	for _, url := range urls {
+		key := cacheKey(url)
+		if tmpPath, ok := rm.pending[key]; ok {
+			os.Remove(tmpPath)
+			delete(rm.pending, key)
+		}
+	}
+}
+
+func (rm *ResourceManager) recordAccess(res Resource) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	for i, existing := range rm.resources {
+		if existing.URL == res.URL {
+			rm.resources[i] = res
+			return
+		}
+	}
+	rm.resources = append(rm.resources, res)
+}
+
+// evictToBudget deletes the least-recently-accessed cache entries (blob +
+// sidecar) until the cache directory's total size is back under
+// rm.byteBudget.
+func (rm *ResourceManager) evictToBudget() {
+	entries, err := os.ReadDir(rm.cacheDir)
+	if err != nil {
+		return
+	}
+
+	type cacheFile struct {
+		key     string
+		size    int64
+		modTime time.Time
+	}
+	byKey := make(map[string]*cacheFile)
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		ext := filepath.Ext(name)
+		if ext != ".blob" && ext != ".json" {
+			continue
+		}
+		key := strings.TrimSuffix(name, ext)
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		cf, ok := byKey[key]
+		if !ok {
+			cf = &cacheFile{key: key}
+			byKey[key] = cf
+		}
+		if ext == ".blob" {
+			cf.size = info.Size()
+		}
+		if info.ModTime().After(cf.modTime) {
+			cf.modTime = info.ModTime()
+		}
+	}
+
+	var total int64
+	files := make([]*cacheFile, 0, len(byKey))
+	for _, cf := range byKey {
+		total += cf.size
+		files = append(files, cf)
+	}
+	if total <= rm.byteBudget {
+		return
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].modTime.Before(files[j].modTime) })
+
+	for _, cf := range files {
+		if total <= rm.byteBudget {
+			break
+		}
+		os.Remove(rm.blobPath(cf.key))
+		os.Remove(rm.sidecarPath(cf.key))
+		total -= cf.size
+	}
+}
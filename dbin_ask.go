@@ -2,20 +2,17 @@ package main
 
 import (
 	"bufio"
-	"crypto/sha256"
-	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"log"
-	"net/http"
 	"net/url"
 	"os"
 	"os/exec"
 	"os/signal"
 	"path/filepath"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -43,96 +40,37 @@ const (
 	screenshotHeight     = 300
 )
 
-type Resource struct {
-	Type string
-	URL  string
-	Path string
-}
-
-type ResourceManager struct {
-	tempDir   string
-	resources []Resource
-}
-
-func NewResourceManager(programID string) (*ResourceManager, error) {
-	uniqueID := BinaryIDString(programID)
-	tempDir := filepath.Join(os.TempDir(), uniqueID)
-	if err := os.MkdirAll(tempDir, 0750); err != nil {
-		return nil, fmt.Errorf("failed to create temp directory: %w", err)
-	}
-
-	rm := &ResourceManager{
-		tempDir:   tempDir,
-		resources: make([]Resource, 0),
-	}
-
-	setupCleanupSignalHandler(rm)
-	return rm, nil
-}
-
-func BinaryIDString(programID string) string {
-	idHash := sha256.Sum256([]byte(programID))
-	return "dbinAsk-" + hex.EncodeToString(idHash[:8])
-}
-
-func setupCleanupSignalHandler(rm *ResourceManager) {
+// setupCleanupSignalHandler makes Ctrl+C / SIGTERM close ui.AbortChan
+// instead of exiting directly, so an in-flight installation is cancelled
+// the same way clicking the Cancel button would cancel it.
+func setupCleanupSignalHandler(ui *UI) {
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 
 	go func() {
 		<-c
-		rm.Cleanup()
-		os.Exit(1)
+		close(ui.AbortChan)
 	}()
 }
 
-func (rm *ResourceManager) Cleanup() {
-	os.RemoveAll(rm.tempDir)
-}
-
-func (rm *ResourceManager) DownloadResource(url, resourceType string) (string, error) {
-	urlHash := sha256.Sum256([]byte(url))
-	fileName := fmt.Sprintf("%s-%s%s",
-		resourceType,
-		hex.EncodeToString(urlHash[:4]),
-		filepath.Ext(url))
-
-	filePath := filepath.Join(rm.tempDir, fileName)
-
-	for _, res := range rm.resources {
-		if res.URL == url {
-			return res.Path, nil
-		}
-	}
+const ResourceTypeLicense = "license"
 
-	file, err := os.Create(filePath)
-	if err != nil {
-		return "", fmt.Errorf("error creating file: %w", err)
-	}
-	defer file.Close()
+const spdxLicenseTextURL = "https://raw.githubusercontent.com/spdx/license-list-data/main/text/%s.txt"
 
-	resp, err := http.Get(url)
+// FetchSPDXLicenseText downloads (and caches via rm) the plain-text body of
+// the given SPDX license identifier, e.g. "MIT" or "Apache-2.0".
+func FetchSPDXLicenseText(rm *ResourceManager, spdxID string) (string, error) {
+	path, err := rm.DownloadResource(fmt.Sprintf(spdxLicenseTextURL, spdxID), ResourceTypeLicense)
 	if err != nil {
-		return "", fmt.Errorf("error downloading %s: %w", resourceType, err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("error downloading %s: status %d", resourceType, resp.StatusCode)
+		return "", err
 	}
 
-	_, err = io.Copy(file, resp.Body)
+	data, err := os.ReadFile(path)
 	if err != nil {
-		return "", fmt.Errorf("error writing %s: %w", resourceType, err)
+		return "", fmt.Errorf("error reading license text: %w", err)
 	}
 
-	rm.resources = append(rm.resources, Resource{
-		Type: resourceType,
-		URL:  url,
-		Path: filePath,
-	})
-
-	return filePath, nil
+	return string(data), nil
 }
 
 func FormatBinaryID(name, pkgID string) string {
@@ -147,12 +85,37 @@ type UI struct {
 	window      fyne.Window
 	resources   *ResourceManager
 	info        *binaryEntry
+	checksums   resourceChecksums
 	program     string
 	iconImage   *canvas.Image
 	screenshots []*canvas.Image
+
+	// programs/infos hold the full batch when more than one package was
+	// requested; program/info above always mirror programs[0]/infos[programs[0]]
+	// so single-package code paths keep working unchanged.
+	programs []string
+	infos    map[string]*binaryEntry
+
+	installDir          string
+	installFinished     bool
+	installationContent fyne.CanvasObject
+
+	// AbortChan is closed when the process receives an interrupt/terminate
+	// signal; it routes external termination through the same cancellation
+	// path as the UI's own Cancel buttons instead of os.Exit.
+	AbortChan chan struct{}
+
+	controlsMu sync.Mutex
+	controls   []*processControl
 }
 
-func NewUI(program string, info binaryEntry) (*UI, error) {
+// NewUI builds the UI for a batch of one or more programs. infos must
+// contain an entry for every program in programs; checksums may contain a
+// resourceChecksums for any subset of them (or none, on older dbin).
+func NewUI(programs []string, infos map[string]*binaryEntry, checksums map[string]resourceChecksums) (*UI, error) {
+	primary := programs[0]
+	info := infos[primary]
+
 	resources, err := NewResourceManager(FormatBinaryID(info.Name, info.PkgId))
 	if err != nil {
 		return nil, err
@@ -167,15 +130,45 @@ func NewUI(program string, info binaryEntry) (*UI, error) {
 		app:         a,
 		window:      w,
 		resources:   resources,
-		info:        &info,
-		program:     program,
+		info:        info,
+		checksums:   checksums[primary],
+		program:     primary,
+		programs:    programs,
+		infos:       infos,
 		screenshots: make([]*canvas.Image, 0),
+		AbortChan:   make(chan struct{}),
 	}
 
 	a.Lifecycle().SetOnStopped(resources.Cleanup)
+	setupCleanupSignalHandler(ui)
+	go ui.watchAbort()
 	return ui, nil
 }
 
+// watchAbort cancels every in-flight installation and quits once AbortChan
+// is closed (by an OS signal or anything else that wants a clean shutdown).
+func (ui *UI) watchAbort() {
+	<-ui.AbortChan
+	ui.CancelAll()
+	ui.resources.Cleanup()
+	ui.app.Quit()
+}
+
+func (ui *UI) registerControl(pc *processControl) {
+	ui.controlsMu.Lock()
+	defer ui.controlsMu.Unlock()
+	ui.controls = append(ui.controls, pc)
+}
+
+// CancelAll cancels every installation process started so far.
+func (ui *UI) CancelAll() {
+	ui.controlsMu.Lock()
+	defer ui.controlsMu.Unlock()
+	for _, pc := range ui.controls {
+		pc.Cancel()
+	}
+}
+
 func (ui *UI) LoadIcon() error {
 	if ui.info.Icon == "" {
 		// Use default icon if none provided
@@ -185,7 +178,7 @@ func (ui *UI) LoadIcon() error {
 		return nil
 	}
 
-	iconPath, err := ui.resources.DownloadResource(ui.info.Icon, ResourceTypeIcon)
+	iconPath, err := ui.resources.downloadResourceChecked(ui.info.Icon, ResourceTypeIcon, ui.checksums.Icon)
 	if err != nil {
 		return err
 	}
@@ -214,7 +207,7 @@ func (ui *UI) LoadScreenshots() error {
 	ui.screenshots = make([]*canvas.Image, 0, len(ui.info.Screenshots))
 
 	for _, url := range ui.info.Screenshots {
-		path, err := ui.resources.DownloadResource(url, ResourceTypeScreenshot)
+		path, err := ui.resources.downloadResourceChecked(url, ResourceTypeScreenshot, ui.checksums.Screenshots[url])
 		if err != nil {
 			log.Printf("Warning: failed to download screenshot %s: %v", url, err)
 			continue
@@ -247,15 +240,37 @@ func (ui *UI) createHeader(title string) fyne.CanvasObject {
 }
 
 func (ui *UI) createInfoTabs() fyne.CanvasObject {
+	return ui.createInfoTabsWithPlan(nil)
+}
+
+// createInfoTabsWithPlan builds the DESCRIPTION/DETAILS/NOTES tabs, plus a
+// DEPENDENCIES tab rendering plan's resolved tree when one is available
+// (dependencyReviewStep resolves it once and passes it in here rather than
+// having every tab rebuild re-invoke dbin).
+func (ui *UI) createInfoTabsWithPlan(plan *Plan) fyne.CanvasObject {
 	tabs := container.NewAppTabs(
 		container.NewTabItem("DESCRIPTION", ui.CreateDescriptionContainer()),
 		container.NewTabItem("DETAILS", ui.CreateMetadataContainer()),
 		container.NewTabItem("NOTES", ui.CreateNotesContainer()),
+		container.NewTabItem("DEPENDENCIES", ui.CreateDependenciesContainer(plan)),
 	)
 	tabs.SetTabLocation(container.TabLocationTop)
 	return tabs
 }
 
+// CreateDependenciesContainer renders plan's resolved dependency tree, or a
+// placeholder while it's still being resolved.
+func (ui *UI) CreateDependenciesContainer(plan *Plan) fyne.CanvasObject {
+	if plan == nil {
+		return widget.NewLabel("Resolving dependencies...")
+	}
+
+	return container.NewVBox(
+		widget.NewLabel(plan.Summary()),
+		newDependencyTreeModel(plan.Root).build(),
+	)
+}
+
 // Don't use scroll containers here, let content expand naturally
 func (ui *UI) CreateDescriptionContainer() fyne.CanvasObject {
 	desc := ui.info.Description
@@ -314,21 +329,17 @@ func (ui *UI) createScreenshotsSection() fyne.CanvasObject {
 	return ui.CreateScreenshotsCarousel()
 }
 
-func (ui *UI) createActionButtons() fyne.CanvasObject {
-	installButton := widget.NewButton("Install", func() {
-		ui.CreateInstallationScreen()
-	})
-
-	cancelButton := widget.NewButton("Cancel", func() {
-		ui.app.Quit()
-	})
-
-	// Use a container that puts Install on left and Cancel on right
-	return container.NewBorder(
-		nil, nil,
-		installButton,
-		cancelButton,
-		nil,
+// packageSummary renders the header, screenshots and info tabs shared by
+// the wizard's pre-installation steps.
+func (ui *UI) packageSummary() fyne.CanvasObject {
+	header := ui.createHeader(FormatBinaryID(ui.info.Name, ui.info.PkgId))
+	return container.NewVBox(
+		header,
+		widget.NewSeparator(),
+		ui.createScreenshotsSection(),
+		widget.NewSeparator(),
+		ui.createInfoTabs(),
+		widget.NewSeparator(),
 	)
 }
 
@@ -386,50 +397,26 @@ func (ui *UI) CreateScreenshotsCarousel() fyne.CanvasObject {
 	return carouselContainer
 }
 
-func (ui *UI) CreateConfirmationScreen() {
-	title := fmt.Sprintf("Install %s", FormatBinaryID(ui.info.Name, ui.info.PkgId))
-	header := ui.createHeader(fmt.Sprintf("Do you wish to proceed with the installation process?"))
-	screenshots := ui.createScreenshotsSection()
-	tabs := ui.createInfoTabs()
-	buttons := ui.createActionButtons()
-
-	// Main content that expands to fill window
-	mainContent := container.NewVBox(
-		header,
-		widget.NewSeparator(),
-		screenshots,
-		widget.NewSeparator(),
-		tabs,
-	)
-
-	// Put it all together with buttons at bottom
-	content := container.NewBorder(
-		nil,
-		buttons,
-		nil, nil,
-		container.NewScroll(mainContent), // Main content is scrollable as needed
-	)
-
-	ui.window.SetContent(content)
-	ui.window.SetTitle(title)
-}
-
+// Run builds the step-based wizard and starts the Fyne event loop.
 func (ui *UI) Run() {
-	ui.CreateConfirmationScreen()
+	wizard := NewWizardController(ui)
+	ui.window.SetContent(wizard.Content())
 	ui.window.ShowAndRun()
 }
 
-func (ui *UI) CreateInstallationScreen() {
+// buildInstallationContent lays out the header/screenshots/tabs/progress
+// section shown while the installationStep is active. It is built once and
+// reused so progress updates don't rebuild the surrounding layout.
+func (ui *UI) buildInstallationContent() (fyne.CanvasObject, *ProgressIndicator, *widget.Label, func(*processControl)) {
 	binaryName := FormatBinaryID(ui.info.Name, ui.info.PkgId)
-	ui.window.SetTitle(fmt.Sprintf("Installing %s", binaryName))
-
 	header := ui.createHeader(fmt.Sprintf("%s is currently being installed into your system", binaryName))
 
 	progressDetails := widget.NewLabel("Preparing installation...")
 	progressDetails.Alignment = fyne.TextAlignCenter
 
-	progressBar := widget.NewProgressBar()
-	progressBar.SetValue(0.0)
+	progress := NewProgressIndicator()
+
+	controls := ui.buildControlButtons(progressDetails)
 
 	var screenshotsContainer fyne.CanvasObject
 	if len(ui.screenshots) > 0 {
@@ -442,7 +429,8 @@ func (ui *UI) CreateInstallationScreen() {
 
 	progressSection := container.NewVBox(
 		progressDetails,
-		progressBar,
+		progress.CanvasObject(),
+		controls.row,
 	)
 
 	infoTabs := container.NewAppTabs(
@@ -452,7 +440,6 @@ func (ui *UI) CreateInstallationScreen() {
 	)
 	infoTabs.SetTabLocation(container.TabLocationTop)
 
-	// Create main content with proper layout
 	mainContent := container.NewVBox(
 		header,
 		widget.NewSeparator(),
@@ -462,26 +449,131 @@ func (ui *UI) CreateInstallationScreen() {
 		progressSection,
 	)
 
-	// Use a scroll container for the entire content
-	content := container.NewScroll(mainContent)
+	return container.NewScroll(mainContent), progress, progressDetails, controls.attach
+}
 
-	ui.window.SetContent(content)
-	go ui.RunInstallation(progressBar, progressDetails)
+// controlButtons is the pause/resume/cancel row shown next to a progress
+// bar. The buttons stay disabled until attach(pc) is called with the
+// processControl for the process that was actually started.
+type controlButtons struct {
+	row       fyne.CanvasObject
+	pauseBtn  *widget.Button
+	resumeBtn *widget.Button
+	cancelBtn *widget.Button
+	attach    func(*processControl)
 }
 
-func (ui *UI) RunInstallation(progressBar *widget.ProgressBar, statusLabel *widget.Label) {
+func (ui *UI) buildControlButtons(statusLabel *widget.Label) *controlButtons {
+	cb := &controlButtons{}
+
+	var pcMu sync.Mutex
+	var pc *processControl
+	getPC := func() *processControl {
+		pcMu.Lock()
+		defer pcMu.Unlock()
+		return pc
+	}
+
+	cb.pauseBtn = widget.NewButton("Pause", func() {
+		pc := getPC()
+		if pc == nil {
+			return
+		}
+		if err := pc.Pause(); err != nil {
+			dialog.ShowError(err, ui.window)
+			return
+		}
+		statusLabel.SetText("Paused")
+		cb.pauseBtn.Disable()
+		cb.resumeBtn.Enable()
+	})
+	cb.resumeBtn = widget.NewButton("Resume", func() {
+		pc := getPC()
+		if pc == nil {
+			return
+		}
+		statusLabel.SetText("Resuming...")
+		if err := pc.Resume(); err != nil {
+			dialog.ShowError(err, ui.window)
+			return
+		}
+		cb.resumeBtn.Disable()
+		cb.pauseBtn.Enable()
+	})
+	cb.cancelBtn = widget.NewButton("Cancel", func() {
+		pc := getPC()
+		if pc == nil {
+			return
+		}
+		statusLabel.SetText("Cancelling...")
+		pc.Cancel()
+		cb.pauseBtn.Disable()
+		cb.resumeBtn.Disable()
+		cb.cancelBtn.Disable()
+	})
+	cb.resumeBtn.Disable()
+	cb.pauseBtn.Disable()
+	cb.cancelBtn.Disable()
+
+	cb.row = container.NewHBox(cb.pauseBtn, cb.resumeBtn, cb.cancelBtn)
+
+	cb.attach = func(started *processControl) {
+		pcMu.Lock()
+		pc = started
+		pcMu.Unlock()
+		cb.pauseBtn.Enable()
+		cb.cancelBtn.Enable()
+	}
+
+	return cb
+}
+
+// StartInstallation runs `dbin install` in the background and calls onDone
+// once the process has finished (successfully or not).
+func (ui *UI) StartInstallation(onDone func()) {
+	if len(ui.programs) > 1 {
+		ui.StartBatchInstallation(onDone)
+		return
+	}
+
+	content, progress, progressDetails, attach := ui.buildInstallationContent()
+	ui.installationContent = content
 	binaryName := FormatBinaryID(ui.info.Name, ui.info.PkgId)
+	go ui.RunInstallation(ui.program, binaryName, progress, progressDetails, attach, onDone)
+}
+
+func (ui *UI) RunInstallation(program, binaryName string, progress *ProgressIndicator, statusLabel *widget.Label, onStart func(*processControl), onDone func()) {
 	statusLabel.SetText("Starting installation...")
 
-	cmd := exec.Command("dbin", "install", ui.program)
+	args := []string{"install", program}
+	if ui.installDir != "" {
+		args = append(args, "--install-dir", ui.installDir)
+	}
+
+	cmd := exec.Command("dbin", args...)
 	cmd.Env = append(os.Environ(), "DBIN_PB_FIFO=1")
 	if err := cmd.Start(); err != nil {
 		dialog.ShowError(fmt.Errorf("Failed to start installation: %w", err), ui.window)
+		onDone()
 		return
 	}
 
 	statusLabel.SetText("Installation in progress...")
-	fifoPath := filepath.Join(os.TempDir(), "dbin", binaryName)
+	fifoPath := progressFIFOPath(binaryName)
+
+	var resourceURLs []string
+	if info := ui.infos[program]; info != nil {
+		if info.Icon != "" {
+			resourceURLs = append(resourceURLs, info.Icon)
+		}
+		resourceURLs = append(resourceURLs, info.Screenshots...)
+	}
+
+	pc := newProcessControl(cmd, fifoPath, ui.resources, resourceURLs)
+	ui.registerControl(pc)
+	if onStart != nil {
+		onStart(pc)
+	}
 
 	fifoReady := make(chan bool, 1)
 	go func() {
@@ -499,119 +591,236 @@ func (ui *UI) RunInstallation(progressBar *widget.ProgressBar, statusLabel *widg
 	case ready := <-fifoReady:
 		if ready {
 			statusLabel.SetText("Monitoring installation progress...")
-			ui.monitorProgress(cmd, fifoPath, progressBar, statusLabel)
+			ui.monitorProgress(cmd, fifoPath, progress, statusLabel, onDone)
 		} else {
 			statusLabel.SetText("Progress monitoring unavailable, installation continuing...")
 			dialog.ShowInformation("Notice",
 				"Progress information not available. Installation is continuing.",
 				ui.window)
-			ui.waitForProcess(cmd, progressBar, statusLabel)
+			ui.waitForProcess(cmd, progress, statusLabel, onDone)
 		}
 	}
 }
 
-func (ui *UI) monitorProgress(cmd *exec.Cmd, fifoPath string, progressBar *widget.ProgressBar, statusLabel *widget.Label) {
+func (ui *UI) monitorProgress(cmd *exec.Cmd, fifoPath string, progress *ProgressIndicator, statusLabel *widget.Label, onDone func()) {
 	fifoFile, err := os.Open(fifoPath)
 	if err != nil {
 		statusLabel.SetText("Cannot monitor progress, waiting for installation to complete...")
-		ui.waitForProcess(cmd, progressBar, statusLabel)
+		ui.waitForProcess(cmd, progress, statusLabel, onDone)
 		return
 	}
 	defer fifoFile.Close()
 
-	var lastPercentage float64 = -1
+	eta := newETAEstimator()
 	scanner := bufio.NewScanner(fifoFile)
 	for scanner.Scan() {
-		line := scanner.Text()
-
-		var percentage float64
-		if _, err := fmt.Sscanf(line, "%f", &percentage); err == nil {
-			if percentage == lastPercentage {
-				continue
-			}
-			lastPercentage = percentage
-
-			progressBar.SetValue(percentage / 100.0)
-			statusLabel.SetText(fmt.Sprintf("Installing... %.1f%%", percentage))
+		event, err := ParseProgressLine(scanner.Bytes())
+		if err != nil {
+			continue
 		}
+
+		progress.Apply(event)
+		statusLabel.SetText(event.StatusLine(eta))
 	}
 
-	ui.waitForProcess(cmd, progressBar, statusLabel)
+	ui.waitForProcess(cmd, progress, statusLabel, onDone)
 }
 
-func (ui *UI) waitForProcess(cmd *exec.Cmd, progressBar *widget.ProgressBar, statusLabel *widget.Label) {
+func (ui *UI) waitForProcess(cmd *exec.Cmd, progress *ProgressIndicator, statusLabel *widget.Label, onDone func()) {
 	err := cmd.Wait()
 
+	progress.SetIndeterminate(false)
 	if err != nil {
-		progressBar.SetValue(1.0)
+		progress.SetValue(1.0)
 		statusLabel.SetText("Installation failed")
 		dialog.ShowError(fmt.Errorf("Installation failed: %w", err), ui.window)
 	} else {
-		progressBar.SetValue(1.0)
+		progress.SetValue(1.0)
 		statusLabel.SetText("Installation completed successfully")
+	}
+	onDone()
+}
 
-		successDialog := dialog.NewInformation("Installation Complete",
-			"The package was installed successfully.", ui.window)
-		successDialog.SetOnClosed(func() {
-			ui.app.Quit()
-		})
-		successDialog.Show()
+// LaunchInstalledProgram starts the just-installed binary and quits the wizard.
+func (ui *UI) LaunchInstalledProgram() {
+	dir := ui.installDir
+	if dir == "" {
+		dir = userInstallDir()
+	}
+	binary := filepath.Join(dir, ui.info.Name)
+
+	if err := exec.Command(binary).Start(); err != nil {
+		dialog.ShowError(fmt.Errorf("failed to launch %s: %w", ui.info.Name, err), ui.window)
+		return
+	}
+	ui.app.Quit()
+}
+
+// ShowInFileManager opens the installed binary's directory with xdg-open.
+func (ui *UI) ShowInFileManager() {
+	dir := ui.installDir
+	if dir == "" {
+		dir = userInstallDir()
+	}
+	if err := exec.Command("xdg-open", dir).Start(); err != nil {
+		dialog.ShowError(fmt.Errorf("failed to open file manager: %w", err), ui.window)
 	}
 }
 
-func ParseInstallURI(uri string) (string, error) {
+// ParseInstallURI parses a dbin://ask/install/<program>[,<program>...] URI
+// and returns the decoded list of requested programs. Multiple programs may
+// be given as a comma-separated segment (.../pkg1,pkg2) or as repeated path
+// segments (.../pkg1/pkg2), or a mix of both. A single program still comes
+// back as a slice of length 1.
+func ParseInstallURI(uri string) ([]string, error) {
 	if !strings.HasPrefix(uri, "dbin://ask/install/") {
-		return "", errors.New("invalid URI format. Was expecting: dbin://ask/install/*")
+		return nil, errors.New("invalid URI format. Was expecting: dbin://ask/install/*")
 	}
 
 	parts := strings.Split(uri, "/")
-	if len(parts) != 5 {
-		return "", errors.New("invalid URI format")
+	if len(parts) < 5 {
+		return nil, errors.New("invalid URI format")
 	}
 
-	programEncoded := parts[4]
-	program, err := url.QueryUnescape(programEncoded)
-	if err != nil {
-		return "", fmt.Errorf("error decoding program: %w", err)
+	var programs []string
+	for _, part := range parts[4:] {
+		for _, segment := range strings.Split(part, ",") {
+			programEncoded := strings.TrimSpace(segment)
+			if programEncoded == "" {
+				continue
+			}
+			program, err := url.QueryUnescape(programEncoded)
+			if err != nil {
+				return nil, fmt.Errorf("error decoding program %q: %w", programEncoded, err)
+			}
+			programs = append(programs, program)
+		}
+	}
+
+	if len(programs) == 0 {
+		return nil, errors.New("invalid URI format: no program specified")
 	}
 
-	return program, nil
+	return programs, nil
+}
+
+// resourceChecksums holds optional SHA-256 digests for an entry's icon and
+// screenshots, decoded straight from `dbin info --json`'s raw output rather
+// than from binaryEntry: older dbin versions (and binaryEntry itself) may
+// not carry these fields, so they come back zero-valued instead of failing
+// to build or silently referencing data that was never there.
+type resourceChecksums struct {
+	Icon        string            `json:"icon_sha256"`
+	Screenshots map[string]string `json:"screenshot_sha256"`
 }
 
-func GetProgramInfo(program string) (*binaryEntry, error) {
+func GetProgramInfo(program string) (*binaryEntry, resourceChecksums, error) {
 	var info binaryEntry
+	var checksums resourceChecksums
 
 	cmd := exec.Command("dbin", "info", "--json", program)
 	output, err := cmd.Output()
 	if err != nil {
-		return nil, fmt.Errorf("error executing dbin info: %w", err)
+		return nil, resourceChecksums{}, fmt.Errorf("error executing dbin info: %w", err)
 	}
 
 	if err := json.Unmarshal(output, &info); err != nil {
-		return nil, fmt.Errorf("error parsing JSON: %w", err)
+		return nil, resourceChecksums{}, fmt.Errorf("error parsing JSON: %w", err)
 	}
+	// Best-effort: absent on dbin versions that don't emit checksums.
+	json.Unmarshal(output, &checksums)
 
-	return &info, nil
+	return &info, checksums, nil
+}
+
+// maxConcurrentInfoFetch bounds how many `dbin info` calls run at once so a
+// large batch URI doesn't spawn one process per package all at once.
+const maxConcurrentInfoFetch = 4
+
+// GetProgramInfoBatch fetches metadata for every program concurrently,
+// bounded by maxConcurrentInfoFetch. The returned maps are keyed by program
+// name; a program present in infos/checksums is absent from errs and vice
+// versa.
+func GetProgramInfoBatch(programs []string) (map[string]*binaryEntry, map[string]resourceChecksums, map[string]error) {
+	infos := make(map[string]*binaryEntry, len(programs))
+	checksums := make(map[string]resourceChecksums, len(programs))
+	errs := make(map[string]error)
+	var mu sync.Mutex
+
+	sem := make(chan struct{}, maxConcurrentInfoFetch)
+	var wg sync.WaitGroup
+
+	for _, program := range programs {
+		wg.Add(1)
+		go func(program string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			info, sums, err := GetProgramInfo(program)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs[program] = err
+				return
+			}
+			infos[program] = info
+			checksums[program] = sums
+		}(program)
+	}
+
+	wg.Wait()
+	return infos, checksums, errs
+}
+
+// filterProgramsWithInfo preserves requested order while dropping any
+// program GetProgramInfoBatch couldn't resolve.
+func filterProgramsWithInfo(programs []string, infos map[string]*binaryEntry) []string {
+	resolved := make([]string, 0, len(programs))
+	for _, program := range programs {
+		if _, ok := infos[program]; ok {
+			resolved = append(resolved, program)
+		}
+	}
+	return resolved
 }
 
 func main() {
+	if len(os.Args) == 2 && os.Args[1] == "--service" {
+		runServiceMode()
+		return
+	}
+
 	if len(os.Args) != 2 {
-		fmt.Println("Usage: dbin-ask dbin://ask/install/program%23id")
+		fmt.Println("Usage: dbin-ask dbin://ask/install/program%23id[,program2%23id...]")
+		fmt.Println("       dbin-ask --service")
 		os.Exit(1)
 	}
 
 	uri := os.Args[1]
-	programID, err := ParseInstallURI(uri)
+
+	if forwarded, err := forwardToRunningInstance(uri); err != nil {
+		log.Printf("Warning: D-Bus forwarding unavailable, opening a new window: %v", err)
+	} else if forwarded {
+		return
+	}
+
+	programs, err := ParseInstallURI(uri)
 	if err != nil {
 		log.Fatalf("Error parsing URI: %v", err)
 	}
 
-	info, err := GetProgramInfo(programID)
-	if err != nil {
-		log.Fatalf("Error getting program info: %v", err)
+	infos, checksums, errs := GetProgramInfoBatch(programs)
+	for program, err := range errs {
+		log.Printf("Warning: failed to get info for %s: %v", program, err)
+	}
+	programs = filterProgramsWithInfo(programs, infos)
+	if len(programs) == 0 {
+		log.Fatalf("Error getting program info: no requested program could be resolved")
 	}
 
-	ui, err := NewUI(programID, *info)
+	ui, err := NewUI(programs, infos, checksums)
 	if err != nil {
 		log.Fatalf("Error creating UI: %v", err)
 	}
@@ -620,5 +829,9 @@ func main() {
 		log.Fatalf("Error initializing UI: %v", err)
 	}
 
+	if _, err := serveDBus(); err != nil {
+		log.Printf("Warning: could not claim %s on the session bus, subsequent dbin:// links will open a new window instead of reusing this one: %v", dbusServiceName, err)
+	}
+
 	ui.Run()
 }
@@ -0,0 +1,290 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/godbus/dbus/v5/introspect"
+)
+
+// dbusServiceName/dbusObjectPath identify the D-Bus service a browser (or
+// any other xdg-desktop-portal client) talks to when it triggers
+// dbin://ask/install/* instead of spawning a fresh dbin-ask process.
+const (
+	dbusServiceName = "xyz.dbin.Ask1"
+	dbusObjectPath  = "/xyz/dbin/Ask1"
+)
+
+// installJob tracks one D-Bus-initiated installation so Cancel can find
+// its processControl.
+type installJob struct {
+	program string
+	control *processControl
+}
+
+// AskService implements the xyz.dbin.Ask1 D-Bus interface: one
+// InstallPackage/Cancel pair of methods and Progress/Finished signals,
+// backed by the same RunInstallation machinery the interactive UI uses.
+type AskService struct {
+	conn *dbus.Conn
+
+	mu        sync.Mutex
+	jobs      map[string]*installJob
+	nextJobID uint64
+}
+
+func newAskService(conn *dbus.Conn) *AskService {
+	return &AskService{conn: conn, jobs: make(map[string]*installJob)}
+}
+
+// InstallPackage starts installing program and returns a job ID clients
+// can use to track it via the Progress/Finished signals or cancel it.
+func (s *AskService) InstallPackage(program string) (string, *dbus.Error) {
+	info, _, err := GetProgramInfo(program)
+	if err != nil {
+		return "", dbus.MakeFailedError(err)
+	}
+
+	jobID := fmt.Sprintf("job-%d", atomic.AddUint64(&s.nextJobID, 1))
+	job := &installJob{program: program}
+
+	s.mu.Lock()
+	s.jobs[jobID] = job
+	s.mu.Unlock()
+
+	binaryName := FormatBinaryID(info.Name, info.PkgId)
+	go s.run(jobID, job, binaryName)
+
+	return jobID, nil
+}
+
+// Cancel cancels a running job started via InstallPackage.
+func (s *AskService) Cancel(jobID string) *dbus.Error {
+	s.mu.Lock()
+	job, ok := s.jobs[jobID]
+	var control *processControl
+	if ok {
+		control = job.control
+	}
+	s.mu.Unlock()
+	if !ok {
+		return dbus.MakeFailedError(fmt.Errorf("unknown job %q", jobID))
+	}
+	if control != nil {
+		control.Cancel()
+	}
+	return nil
+}
+
+// run drives one headless installation, the D-Bus counterpart of
+// UI.RunInstallation, emitting Progress/Finished signals instead of
+// updating Fyne widgets.
+func (s *AskService) run(jobID string, job *installJob, binaryName string) {
+	cmd := exec.Command("dbin", "install", job.program)
+	cmd.Env = append(os.Environ(), "DBIN_PB_FIFO=1")
+	if err := cmd.Start(); err != nil {
+		s.emitFinished(jobID, false, err.Error())
+		return
+	}
+
+	fifoPath := progressFIFOPath(binaryName)
+	control := newProcessControl(cmd, fifoPath, nil, nil)
+	s.mu.Lock()
+	job.control = control
+	s.mu.Unlock()
+
+	for attempts := 0; attempts < 50; attempts++ {
+		if _, err := os.Stat(fifoPath); err == nil {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	if fifoFile, err := os.Open(fifoPath); err == nil {
+		scanner := bufio.NewScanner(fifoFile)
+		for scanner.Scan() {
+			event, err := ParseProgressLine(scanner.Bytes())
+			if err != nil {
+				continue
+			}
+			s.emitProgress(jobID, event.Fraction()*100, string(event.Phase))
+		}
+		fifoFile.Close()
+	}
+
+	err := cmd.Wait()
+	if err != nil {
+		s.emitFinished(jobID, false, err.Error())
+		return
+	}
+	s.emitFinished(jobID, true, "")
+}
+
+func (s *AskService) emitProgress(jobID string, percent float64, phase string) {
+	if err := s.conn.Emit(dbus.ObjectPath(dbusObjectPath), dbusServiceName+".Progress", jobID, percent, phase); err != nil {
+		log.Printf("Warning: failed to emit Progress signal: %v", err)
+	}
+}
+
+func (s *AskService) emitFinished(jobID string, success bool, errMsg string) {
+	if err := s.conn.Emit(dbus.ObjectPath(dbusObjectPath), dbusServiceName+".Finished", jobID, success, errMsg); err != nil {
+		log.Printf("Warning: failed to emit Finished signal: %v", err)
+	}
+}
+
+// serveDBus claims dbusServiceName on the session bus and exports
+// AskService on it. It's a no-op (not an error) if another instance
+// already owns the name, since that's the expected single-instance case.
+func serveDBus() (*dbus.Conn, error) {
+	conn, err := dbus.SessionBus()
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to session bus: %w", err)
+	}
+
+	reply, err := conn.RequestName(dbusServiceName, dbus.NameFlagDoNotQueue)
+	if err != nil {
+		return nil, fmt.Errorf("error requesting D-Bus name: %w", err)
+	}
+	if reply != dbus.RequestNameReplyPrimaryOwner {
+		return nil, nil
+	}
+
+	service := newAskService(conn)
+	if err := conn.Export(service, dbus.ObjectPath(dbusObjectPath), dbusServiceName); err != nil {
+		return nil, fmt.Errorf("error exporting D-Bus service: %w", err)
+	}
+
+	node := &introspect.Node{
+		Name: dbusObjectPath,
+		Interfaces: []introspect.Interface{
+			introspect.IntrospectData,
+			{
+				Name: dbusServiceName,
+				Methods: []introspect.Method{
+					{Name: "InstallPackage", Args: []introspect.Arg{
+						{Name: "program", Type: "s", Direction: "in"},
+						{Name: "job_id", Type: "s", Direction: "out"},
+					}},
+					{Name: "Cancel", Args: []introspect.Arg{
+						{Name: "job_id", Type: "s", Direction: "in"},
+					}},
+				},
+				Signals: []introspect.Signal{
+					{Name: "Progress", Args: []introspect.Arg{
+						{Name: "job_id", Type: "s"},
+						{Name: "percent", Type: "d"},
+						{Name: "phase", Type: "s"},
+					}},
+					{Name: "Finished", Args: []introspect.Arg{
+						{Name: "job_id", Type: "s"},
+						{Name: "success", Type: "b"},
+						{Name: "error", Type: "s"},
+					}},
+				},
+			},
+		},
+	}
+	if err := conn.Export(introspect.NewIntrospectable(node), dbus.ObjectPath(dbusObjectPath), "org.freedesktop.DBus.Introspectable"); err != nil {
+		return nil, fmt.Errorf("error exporting introspection data: %w", err)
+	}
+
+	return conn, nil
+}
+
+// forwardToRunningInstance checks whether another dbin-ask instance
+// already owns dbusServiceName and, if so, forwards every program in uri
+// to it via InstallPackage instead of opening a second window.
+func forwardToRunningInstance(uri string) (bool, error) {
+	conn, err := dbus.SessionBus()
+	if err != nil {
+		return false, fmt.Errorf("error connecting to session bus: %w", err)
+	}
+
+	var hasOwner bool
+	if err := conn.BusObject().Call("org.freedesktop.DBus.NameHasOwner", 0, dbusServiceName).Store(&hasOwner); err != nil {
+		return false, fmt.Errorf("error querying D-Bus name ownership: %w", err)
+	}
+	if !hasOwner {
+		return false, nil
+	}
+
+	programs, err := ParseInstallURI(uri)
+	if err != nil {
+		return false, err
+	}
+
+	obj := conn.Object(dbusServiceName, dbus.ObjectPath(dbusObjectPath))
+	for _, program := range programs {
+		var jobID string
+		if err := obj.Call(dbusServiceName+".InstallPackage", 0, program).Store(&jobID); err != nil {
+			return false, fmt.Errorf("error forwarding %s to running instance: %w", program, err)
+		}
+	}
+
+	return true, nil
+}
+
+// desktopFileTemplate registers dbin-ask as the handler for the
+// dbin://ask/install/* URL scheme so a browser click invokes it directly.
+const desktopFileTemplate = `[Desktop Entry]
+Type=Application
+Name=dbin-ask
+Comment=Install packages served via dbin:// links
+Exec=dbin-ask %u
+NoDisplay=true
+MimeType=x-scheme-handler/dbin;
+`
+
+// installDesktopFile writes the .desktop file and registers it as the
+// default handler for the dbin:// URL scheme via xdg-mime.
+func installDesktopFile() error {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("failed to resolve home directory: %w", err)
+	}
+
+	appsDir := filepath.Join(home, ".local", "share", "applications")
+	if err := os.MkdirAll(appsDir, 0750); err != nil {
+		return fmt.Errorf("failed to create applications directory: %w", err)
+	}
+
+	desktopPath := filepath.Join(appsDir, "dbin-ask.desktop")
+	if err := os.WriteFile(desktopPath, []byte(desktopFileTemplate), 0640); err != nil {
+		return fmt.Errorf("failed to write desktop file: %w", err)
+	}
+
+	cmd := exec.Command("xdg-mime", "default", "dbin-ask.desktop", "x-scheme-handler/dbin")
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to register dbin:// URL scheme handler: %w", err)
+	}
+
+	return nil
+}
+
+// runServiceMode installs the desktop file/URL scheme handler, claims the
+// D-Bus service name, and blocks forever handling InstallPackage calls
+// from other processes (e.g. a browser). Used for `dbin-ask --service`.
+func runServiceMode() {
+	if err := installDesktopFile(); err != nil {
+		log.Printf("Warning: failed to register URL scheme handler: %v", err)
+	}
+
+	conn, err := serveDBus()
+	if err != nil {
+		log.Fatalf("Error starting D-Bus service: %v", err)
+	}
+	if conn == nil {
+		log.Fatalf("Error starting D-Bus service: %s is already owned by another instance", dbusServiceName)
+	}
+
+	log.Printf("dbin-ask service listening on %s", dbusServiceName)
+	select {}
+}
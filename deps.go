@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+)
+
+// PlanNode is one package in a resolved dependency closure: the requested
+// program itself, or one of its (transitive) dependencies.
+type PlanNode struct {
+	Name      string      `json:"name"`
+	Version   string      `json:"version"`
+	Size      string      `json:"size"`
+	SizeBytes int64       `json:"size_bytes"`
+	Installed bool        `json:"installed"`
+	Children  []*PlanNode `json:"children"`
+}
+
+// PlanConflict describes two resolved versions of the same package that
+// can't both be installed.
+type PlanConflict struct {
+	PackageA string `json:"package_a"`
+	VersionA string `json:"version_a"`
+	PackageB string `json:"package_b"`
+	VersionB string `json:"version_b"`
+	Reason   string `json:"reason"`
+}
+
+// Describe renders a PlanConflict as the one-line text shown next to its
+// "Resolve..." button.
+func (c PlanConflict) Describe() string {
+	return fmt.Sprintf("%s %s conflicts with %s %s: %s", c.PackageA, c.VersionA, c.PackageB, c.VersionB, c.Reason)
+}
+
+// Plan is the transitive dependency closure dbin would install alongside a
+// requested program, as resolved by `dbin deps --json`.
+type Plan struct {
+	Root           *PlanNode      `json:"root"`
+	NewPackages    int            `json:"new_packages"`
+	DownloadBytes  int64          `json:"download_bytes"`
+	InstalledBytes int64          `json:"installed_bytes"`
+	Conflicts      []PlanConflict `json:"conflicts"`
+}
+
+// Summary renders the "N new packages, X MiB to download, Y MiB after
+// install" line shown above the dependency tree.
+func (p *Plan) Summary() string {
+	return fmt.Sprintf("%d new packages, %s to download, %s after install",
+		p.NewPackages, formatBytes(p.DownloadBytes), formatBytes(p.InstalledBytes))
+}
+
+// ResolvePlan asks dbin to resolve the transitive dependency closure for
+// program, analogous to GetProgramInfo.
+func ResolvePlan(program string) (*Plan, error) {
+	cmd := exec.Command("dbin", "deps", "--json", program)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("error executing dbin deps: %w", err)
+	}
+
+	var plan Plan
+	if err := json.Unmarshal(output, &plan); err != nil {
+		return nil, fmt.Errorf("error parsing JSON: %w", err)
+	}
+
+	return &plan, nil
+}
+
+// dependencyTreeModel adapts a Plan's PlanNode tree to fyne's widget.Tree,
+// which addresses nodes by string ID rather than by pointer.
+type dependencyTreeModel struct {
+	nodes    map[widget.TreeNodeID]*PlanNode
+	children map[widget.TreeNodeID][]widget.TreeNodeID
+}
+
+func newDependencyTreeModel(root *PlanNode) *dependencyTreeModel {
+	m := &dependencyTreeModel{
+		nodes:    make(map[widget.TreeNodeID]*PlanNode),
+		children: make(map[widget.TreeNodeID][]widget.TreeNodeID),
+	}
+
+	// root is nil when dbin's output carries no root node; leave the model
+	// empty rather than dereferencing it.
+	if root == nil {
+		m.children[""] = []widget.TreeNodeID{}
+		return m
+	}
+
+	var walk func(id widget.TreeNodeID, node *PlanNode)
+	walk = func(id widget.TreeNodeID, node *PlanNode) {
+		m.nodes[id] = node
+		childIDs := make([]widget.TreeNodeID, 0, len(node.Children))
+		for i, child := range node.Children {
+			childID := widget.TreeNodeID(fmt.Sprintf("%s.%d", id, i))
+			childIDs = append(childIDs, childID)
+			walk(childID, child)
+		}
+		m.children[id] = childIDs
+	}
+	walk("root", root)
+	m.children[""] = []widget.TreeNodeID{"root"}
+
+	return m
+}
+
+// build renders the model as a collapsible fyne Tree, one label per node
+// showing name, version, size and installed status.
+func (m *dependencyTreeModel) build() *widget.Tree {
+	return widget.NewTree(
+		func(id widget.TreeNodeID) []widget.TreeNodeID { return m.children[id] },
+		func(id widget.TreeNodeID) bool { return len(m.children[id]) > 0 },
+		func(branch bool) fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.TreeNodeID, branch bool, obj fyne.CanvasObject) {
+			node, ok := m.nodes[id]
+			if !ok {
+				return
+			}
+			status := "new"
+			if node.Installed {
+				status = "installed"
+			}
+			obj.(*widget.Label).SetText(fmt.Sprintf("%s %s (%s, %s)", node.Name, node.Version, node.Size, status))
+		},
+	)
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// cancelGracePeriod is how long Cancel waits after SIGTERM before
+// escalating to SIGKILL.
+const cancelGracePeriod = 3 * time.Second
+
+// progressFIFOPath returns the DBIN_PB_FIFO path dbin writes progress
+// events to for binaryName, namespaced under its own subdirectory so that
+// cancelling one package's install (which removes filepath.Dir of this
+// path) can't delete another in-flight package's FIFO out from under it.
+func progressFIFOPath(binaryName string) string {
+	return filepath.Join(os.TempDir(), "dbin", binaryName, "progress")
+}
+
+// processControl lets the UI pause, resume or cancel an in-flight
+// `dbin install` invocation started by RunInstallation.
+type processControl struct {
+	cmd          *exec.Cmd
+	fifoPath     string
+	resources    *ResourceManager
+	resourceURLs []string
+
+	mu        sync.Mutex
+	paused    bool
+	cancelled bool
+}
+
+// newProcessControl wraps cmd/fifoPath for pause/resume/cancel. rm and
+// resourceURLs are optional (nil/empty when the caller doesn't fetch any
+// icon/screenshot resources for this installation, e.g. the D-Bus service
+// path): when set, Cancel aborts any of resourceURLs still mid-download
+// instead of leaving an orphaned partial blob in rm's cache.
+func newProcessControl(cmd *exec.Cmd, fifoPath string, rm *ResourceManager, resourceURLs []string) *processControl {
+	return &processControl{cmd: cmd, fifoPath: fifoPath, resources: rm, resourceURLs: resourceURLs}
+}
+
+// Pause sends SIGSTOP to the installation process.
+func (pc *processControl) Pause() error {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	if pc.paused || pc.cancelled {
+		return nil
+	}
+	if err := pc.cmd.Process.Signal(syscall.SIGSTOP); err != nil {
+		return fmt.Errorf("failed to pause installation: %w", err)
+	}
+	pc.paused = true
+	return nil
+}
+
+// Resume sends SIGCONT to a previously paused installation process.
+func (pc *processControl) Resume() error {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	if !pc.paused || pc.cancelled {
+		return nil
+	}
+	if err := pc.cmd.Process.Signal(syscall.SIGCONT); err != nil {
+		return fmt.Errorf("failed to resume installation: %w", err)
+	}
+	pc.paused = false
+	return nil
+}
+
+// Cancel sends SIGTERM, escalating to SIGKILL after cancelGracePeriod if
+// the process hasn't exited, removes the per-package FIFO, and
+// cleans up any partially-downloaded resource (icon, screenshot) this
+// installation was still fetching.
+func (pc *processControl) Cancel() {
+	pc.mu.Lock()
+	if pc.cancelled {
+		pc.mu.Unlock()
+		return
+	}
+	pc.cancelled = true
+	pc.mu.Unlock()
+
+	if pc.paused {
+		pc.cmd.Process.Signal(syscall.SIGCONT)
+	}
+	pc.cmd.Process.Signal(syscall.SIGTERM)
+
+	go func() {
+		time.Sleep(cancelGracePeriod)
+		pc.cmd.Process.Signal(syscall.SIGKILL)
+	}()
+
+	os.RemoveAll(filepath.Dir(pc.fifoPath))
+
+	if pc.resources != nil {
+		pc.resources.AbortURLs(pc.resourceURLs)
+	}
+}
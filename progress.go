@@ -0,0 +1,222 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// ProgressPhase identifies which stage of an installation a ProgressEvent
+// describes.
+type ProgressPhase string
+
+const (
+	PhaseDownload ProgressPhase = "download"
+	PhaseVerify   ProgressPhase = "verify"
+	PhaseExtract  ProgressPhase = "extract"
+	PhaseLink     ProgressPhase = "link"
+)
+
+// ProgressEvent is one line of the DBIN_PB_FIFO wire format: either a JSON
+// object with these fields, or (for backwards compatibility) a bare "%f"
+// percentage, which ParseProgressLine normalizes into a download-phase
+// event with Total set to 100.
+type ProgressEvent struct {
+	Phase    ProgressPhase `json:"phase"`
+	Current  int64         `json:"current"`
+	Total    int64         `json:"total"`
+	SpeedBps float64       `json:"speed_bps"`
+	File     string        `json:"file"`
+}
+
+// Fraction returns Current/Total, or 0 if Total is unknown/zero.
+func (e ProgressEvent) Fraction() float64 {
+	if e.Total <= 0 {
+		return 0
+	}
+	return float64(e.Current) / float64(e.Total)
+}
+
+// ParseProgressLine parses one line from DBIN_PB_FIFO. It accepts a JSON
+// ProgressEvent object, or a legacy bare float percentage for backwards
+// compatibility with older dbin builds.
+func ParseProgressLine(line []byte) (ProgressEvent, error) {
+	trimmed := bytes.TrimSpace(line)
+	if len(trimmed) == 0 {
+		return ProgressEvent{}, errors.New("empty progress line")
+	}
+
+	if trimmed[0] == '{' {
+		var event ProgressEvent
+		if err := json.Unmarshal(trimmed, &event); err != nil {
+			return ProgressEvent{}, fmt.Errorf("error parsing progress JSON: %w", err)
+		}
+		return event, nil
+	}
+
+	var percent float64
+	if _, err := fmt.Sscanf(string(trimmed), "%f", &percent); err != nil {
+		return ProgressEvent{}, fmt.Errorf("error parsing progress line: %w", err)
+	}
+	return ProgressEvent{Phase: PhaseDownload, Current: int64(percent), Total: 100}, nil
+}
+
+// etaEstimator smooths speed_bps samples with an exponentially-weighted
+// moving average so the displayed ETA doesn't jitter with every sample.
+type etaEstimator struct {
+	alpha     float64
+	avgSpeed  float64
+	hasSample bool
+}
+
+func newETAEstimator() *etaEstimator {
+	return &etaEstimator{alpha: 0.2}
+}
+
+// Update folds in a new speed sample and returns the smoothed speed.
+func (e *etaEstimator) Update(speedBps float64) float64 {
+	if !e.hasSample {
+		e.avgSpeed = speedBps
+		e.hasSample = true
+	} else {
+		e.avgSpeed = e.alpha*speedBps + (1-e.alpha)*e.avgSpeed
+	}
+	return e.avgSpeed
+}
+
+// ETA estimates the remaining time to transfer `remaining` bytes at the
+// current smoothed speed. Returns 0 if the speed isn't known yet.
+func (e *etaEstimator) ETA(remaining int64) time.Duration {
+	if e.avgSpeed <= 0 {
+		return 0
+	}
+	return time.Duration(float64(remaining) / e.avgSpeed * float64(time.Second))
+}
+
+// formatBytes renders a byte count as a human-readable size (KiB/MiB/GiB).
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// formatSpeed renders a bytes/second rate as a human-readable throughput.
+func formatSpeed(bps float64) string {
+	return formatBytes(int64(bps)) + "/s"
+}
+
+// formatETA renders a duration as a short "Xm Ys" / "Zs" estimate.
+func formatETA(d time.Duration) string {
+	if d <= 0 {
+		return "unknown"
+	}
+	d = d.Round(time.Second)
+	if d >= time.Minute {
+		return fmt.Sprintf("%dm %ds", d/time.Minute, (d%time.Minute)/time.Second)
+	}
+	return fmt.Sprintf("%ds", d/time.Second)
+}
+
+// StatusLine renders a ProgressEvent as the text shown under a progress
+// bar, folding e.SpeedBps into eta's smoothed speed when Total is known.
+func (e ProgressEvent) StatusLine(eta *etaEstimator) string {
+	phase := string(e.Phase)
+	if phase == "" {
+		phase = string(PhaseDownload)
+	}
+
+	if e.Total <= 0 {
+		if e.File != "" {
+			return fmt.Sprintf("%s: %s...", phase, e.File)
+		}
+		return phase + "..."
+	}
+
+	speed := eta.Update(e.SpeedBps)
+	remaining := e.Total - e.Current
+	return fmt.Sprintf("%s: %s / %s (%s, ETA %s)",
+		phase, formatBytes(e.Current), formatBytes(e.Total), formatSpeed(speed), formatETA(eta.ETA(remaining)))
+}
+
+// ProgressIndicator wraps a determinate widget.ProgressBar and an
+// indeterminate widget.ProgressBarInfinite in the same slot, switching
+// between them as ProgressEvents report a known or unknown total.
+type ProgressIndicator struct {
+	stack         *fyne.Container
+	bar           *widget.ProgressBar
+	infinite      *widget.ProgressBarInfinite
+	indeterminate bool
+}
+
+func NewProgressIndicator() *ProgressIndicator {
+	bar := widget.NewProgressBar()
+	infinite := widget.NewProgressBarInfinite()
+	infinite.Hide()
+
+	return &ProgressIndicator{
+		stack:    container.NewMax(bar, infinite),
+		bar:      bar,
+		infinite: infinite,
+	}
+}
+
+// CanvasObject returns the object to place in a layout.
+func (p *ProgressIndicator) CanvasObject() fyne.CanvasObject {
+	return p.stack
+}
+
+// SetValue sets the determinate fraction (0..1); ignored while indeterminate.
+func (p *ProgressIndicator) SetValue(fraction float64) {
+	if p.indeterminate {
+		return
+	}
+	p.bar.SetValue(fraction)
+}
+
+// Value returns the last determinate fraction set.
+func (p *ProgressIndicator) Value() float64 {
+	return p.bar.Value
+}
+
+// SetIndeterminate toggles between the spinning bar (unknown total) and the
+// normal percentage bar.
+func (p *ProgressIndicator) SetIndeterminate(indeterminate bool) {
+	if indeterminate == p.indeterminate {
+		return
+	}
+	p.indeterminate = indeterminate
+
+	if indeterminate {
+		p.bar.Hide()
+		p.infinite.Show()
+		p.infinite.Start()
+		return
+	}
+
+	p.infinite.Stop()
+	p.infinite.Hide()
+	p.bar.Show()
+}
+
+// Apply updates the indicator from a single ProgressEvent.
+func (p *ProgressIndicator) Apply(event ProgressEvent) {
+	if event.Total <= 0 {
+		p.SetIndeterminate(true)
+		return
+	}
+	p.SetIndeterminate(false)
+	p.SetValue(event.Fraction())
+}
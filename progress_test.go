@@ -0,0 +1,54 @@
+package main
+
+import "testing"
+
+func TestParseProgressLineJSON(t *testing.T) {
+	line := []byte(`{"phase":"download","current":50,"total":200,"speed_bps":1024,"file":"foo.tar.gz"}`)
+
+	event, err := ParseProgressLine(line)
+	if err != nil {
+		t.Fatalf("ParseProgressLine returned error: %v", err)
+	}
+
+	if event.Phase != PhaseDownload {
+		t.Errorf("Phase = %q, want %q", event.Phase, PhaseDownload)
+	}
+	if event.Current != 50 || event.Total != 200 {
+		t.Errorf("Current/Total = %d/%d, want 50/200", event.Current, event.Total)
+	}
+	if event.SpeedBps != 1024 {
+		t.Errorf("SpeedBps = %v, want 1024", event.SpeedBps)
+	}
+	if event.File != "foo.tar.gz" {
+		t.Errorf("File = %q, want %q", event.File, "foo.tar.gz")
+	}
+}
+
+func TestParseProgressLineLegacyFloat(t *testing.T) {
+	event, err := ParseProgressLine([]byte("42.5\n"))
+	if err != nil {
+		t.Fatalf("ParseProgressLine returned error: %v", err)
+	}
+
+	if event.Phase != PhaseDownload {
+		t.Errorf("Phase = %q, want %q", event.Phase, PhaseDownload)
+	}
+	if event.Current != 42 || event.Total != 100 {
+		t.Errorf("Current/Total = %d/%d, want 42/100", event.Current, event.Total)
+	}
+}
+
+func TestParseProgressLineEmpty(t *testing.T) {
+	if _, err := ParseProgressLine([]byte("   \n")); err == nil {
+		t.Error("expected an error for an empty line, got nil")
+	}
+}
+
+func TestParseProgressLineInvalid(t *testing.T) {
+	if _, err := ParseProgressLine([]byte("not a number")); err == nil {
+		t.Error("expected an error for a non-numeric legacy line, got nil")
+	}
+	if _, err := ParseProgressLine([]byte(`{"phase": `)); err == nil {
+		t.Error("expected an error for truncated JSON, got nil")
+	}
+}
@@ -0,0 +1,537 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Translations maps a locale code (e.g. "en", "es") to a set of UI string keys.
+type Translations map[string]map[string]string
+
+var uiTranslations = Translations{
+	"en": {
+		"language.title":  "Choose your language",
+		"license.title":   "License Agreement",
+		"license.accept":  "I accept the terms of this license",
+		"location.title":  "Install Location",
+		"location.user":   "Install for current user only",
+		"location.system": "Install for all users (system-wide)",
+		"deps.title":      "Dependencies",
+		"nav.back":        "Back",
+		"nav.next":        "Next",
+		"nav.cancel":      "Cancel",
+		"nav.install":     "Install",
+		"nav.finish":      "Finish",
+		"complete.title":       "Installation Complete",
+		"complete.launch":      "Launch",
+		"complete.showInFiles": "Show in File Manager",
+	},
+	"es": {
+		"language.title":  "Elige tu idioma",
+		"license.title":   "Acuerdo de Licencia",
+		"license.accept":  "Acepto los términos de esta licencia",
+		"location.title":  "Ubicación de Instalación",
+		"location.user":   "Instalar solo para el usuario actual",
+		"location.system": "Instalar para todos los usuarios (en todo el sistema)",
+		"deps.title":      "Dependencias",
+		"nav.back":        "Atrás",
+		"nav.next":        "Siguiente",
+		"nav.cancel":      "Cancelar",
+		"nav.install":     "Instalar",
+		"nav.finish":      "Terminar",
+		"complete.title":       "Instalación Completa",
+		"complete.launch":      "Iniciar",
+		"complete.showInFiles": "Mostrar en el Gestor de Archivos",
+	},
+}
+
+const defaultLocale = "en"
+
+// T returns the translated string for key in the wizard's current locale,
+// falling back to the default locale and finally the key itself.
+func (wc *WizardController) T(key string) string {
+	if strs, ok := uiTranslations[wc.locale]; ok {
+		if s, ok := strs[key]; ok {
+			return s
+		}
+	}
+	if strs, ok := uiTranslations[defaultLocale]; ok {
+		if s, ok := strs[key]; ok {
+			return s
+		}
+	}
+	return key
+}
+
+// Step is one screen of the installation wizard.
+type Step interface {
+	Title() string
+	Content() fyne.CanvasObject
+	CanAdvance() bool
+}
+
+// stepEnterer is implemented by steps that need to react to becoming visible.
+type stepEnterer interface {
+	OnEnter()
+}
+
+// WizardController drives a sequence of Steps, rendering a progress
+// indicator and Back/Next/Cancel navigation around whatever the current
+// step contributes.
+type WizardController struct {
+	ui      *UI
+	locale  string
+	steps   []Step
+	current int
+
+	progress *widget.ProgressBar
+	stepBody *fyne.Container
+	backBtn  *widget.Button
+	nextBtn  *widget.Button
+	root     *fyne.Container
+}
+
+// NewWizardController builds the controller and its steps for ui.
+func NewWizardController(ui *UI) *WizardController {
+	wc := &WizardController{
+		ui:     ui,
+		locale: defaultLocale,
+	}
+
+	wc.steps = []Step{
+		newLanguageStep(wc),
+		newLicenseStep(wc),
+		newInstallLocationStep(wc),
+		newDependencyReviewStep(wc),
+		newInstallationStep(wc),
+		newCompletionStep(wc),
+	}
+
+	wc.progress = widget.NewProgressBar()
+	wc.stepBody = container.NewVBox()
+
+	wc.backBtn = widget.NewButton(wc.T("nav.back"), func() { wc.Back() })
+	wc.nextBtn = widget.NewButton(wc.T("nav.next"), func() { wc.Next() })
+	cancelBtn := widget.NewButton(wc.T("nav.cancel"), func() { wc.Cancel() })
+
+	nav := container.NewBorder(nil, nil, wc.backBtn, container.NewHBox(cancelBtn, wc.nextBtn))
+
+	wc.root = container.NewBorder(
+		wc.progress,
+		nav,
+		nil, nil,
+		container.NewScroll(wc.stepBody),
+	)
+
+	return wc
+}
+
+// SetLocale changes the active language and re-renders the current step.
+func (wc *WizardController) SetLocale(locale string) {
+	if locale == wc.locale {
+		return
+	}
+	wc.locale = locale
+	wc.refreshNavLabels()
+	wc.render()
+}
+
+func (wc *WizardController) refreshNavLabels() {
+	wc.backBtn.SetText(wc.T("nav.back"))
+	if wc.current == len(wc.steps)-1 {
+		wc.nextBtn.SetText(wc.T("nav.finish"))
+	} else {
+		wc.nextBtn.SetText(wc.T("nav.next"))
+	}
+}
+
+// Content returns the wizard's root canvas object, to be set on the window.
+func (wc *WizardController) Content() fyne.CanvasObject {
+	wc.render()
+	return wc.root
+}
+
+func (wc *WizardController) render() {
+	step := wc.steps[wc.current]
+	wc.ui.window.SetTitle(fmt.Sprintf("%s - %s", FormatBinaryID(wc.ui.info.Name, wc.ui.info.PkgId), step.Title()))
+
+	wc.progress.SetValue(float64(wc.current) / float64(len(wc.steps)-1))
+
+	if enterer, ok := step.(stepEnterer); ok {
+		enterer.OnEnter()
+	}
+
+	wc.stepBody.Objects = []fyne.CanvasObject{step.Content()}
+	wc.stepBody.Refresh()
+
+	wc.backBtn.Enable()
+	if wc.current == 0 {
+		wc.backBtn.Disable()
+	}
+
+	wc.refreshNavLabels()
+	if step.CanAdvance() {
+		wc.nextBtn.Enable()
+	} else {
+		wc.nextBtn.Disable()
+	}
+}
+
+// Next advances to the following step, or closes the wizard if already on
+// the last one.
+func (wc *WizardController) Next() {
+	if !wc.steps[wc.current].CanAdvance() {
+		return
+	}
+	if wc.current == len(wc.steps)-1 {
+		wc.ui.app.Quit()
+		return
+	}
+	wc.current++
+	wc.render()
+}
+
+// Back returns to the previous step, if any.
+func (wc *WizardController) Back() {
+	if wc.current == 0 {
+		return
+	}
+	wc.current--
+	wc.render()
+}
+
+// Cancel aborts the wizard and quits the application.
+func (wc *WizardController) Cancel() {
+	wc.ui.app.Quit()
+}
+
+// Refresh updates the navigation labels and the Next button's enabled state
+// for the current step without rebuilding its content, used by steps whose
+// CanAdvance state changes asynchronously (e.g. a checkbox or a background fetch).
+func (wc *WizardController) Refresh() {
+	wc.refreshNavLabels()
+	if wc.steps[wc.current].CanAdvance() {
+		wc.nextBtn.Enable()
+	} else {
+		wc.nextBtn.Disable()
+	}
+}
+
+// languageStep lets the user pick the UI locale.
+type languageStep struct {
+	wc *WizardController
+}
+
+func newLanguageStep(wc *WizardController) *languageStep { return &languageStep{wc: wc} }
+
+func (s *languageStep) Title() string     { return s.wc.T("language.title") }
+func (s *languageStep) CanAdvance() bool  { return true }
+func (s *languageStep) Content() fyne.CanvasObject {
+	locales := make([]string, 0, len(uiTranslations))
+	for locale := range uiTranslations {
+		locales = append(locales, locale)
+	}
+
+	selector := widget.NewSelect(locales, func(locale string) {
+		s.wc.SetLocale(locale)
+	})
+	selector.SetSelected(s.wc.locale)
+
+	title := widget.NewLabel(s.Title())
+	title.TextStyle.Bold = true
+
+	return container.NewVBox(s.wc.ui.packageSummary(), title, selector)
+}
+
+// licenseStep fetches and displays the SPDX license text for each license
+// in binaryEntry.License, requiring acceptance before Next is enabled.
+type licenseStep struct {
+	wc       *WizardController
+	accepted bool
+	fetched  bool
+	text     string
+}
+
+func newLicenseStep(wc *WizardController) *licenseStep { return &licenseStep{wc: wc} }
+
+func (s *licenseStep) Title() string    { return s.wc.T("license.title") }
+func (s *licenseStep) CanAdvance() bool { return s.accepted }
+
+func (s *licenseStep) OnEnter() {
+	if s.fetched {
+		return
+	}
+	s.fetched = true
+
+	go func() {
+		var parts []string
+		for _, id := range s.wc.ui.info.License {
+			text, err := FetchSPDXLicenseText(s.wc.ui.resources, id)
+			if err != nil {
+				log.Printf("Warning: failed to fetch license %s: %v", id, err)
+				parts = append(parts, fmt.Sprintf("## %s\n\n*License text unavailable: %v*", id, err))
+				continue
+			}
+			parts = append(parts, fmt.Sprintf("## %s\n\n```\n%s\n```", id, text))
+		}
+		s.text = strings.Join(parts, "\n\n")
+		s.wc.render()
+	}()
+}
+
+func (s *licenseStep) Content() fyne.CanvasObject {
+	title := widget.NewLabel(s.Title())
+	title.TextStyle.Bold = true
+
+	body := s.text
+	if body == "" {
+		body = "Fetching license text..."
+	}
+	richText := widget.NewRichTextFromMarkdown(body)
+	richText.Wrapping = fyne.TextWrapWord
+
+	accept := widget.NewCheck(s.wc.T("license.accept"), func(checked bool) {
+		s.accepted = checked
+		s.wc.Refresh()
+	})
+	accept.SetChecked(s.accepted)
+
+	return container.NewVBox(title, container.NewScroll(richText), accept)
+}
+
+// installLocationStep offers a user vs. system install location.
+type installLocationStep struct {
+	wc     *WizardController
+	system bool
+}
+
+func newInstallLocationStep(wc *WizardController) *installLocationStep {
+	return &installLocationStep{wc: wc}
+}
+
+func (s *installLocationStep) Title() string    { return s.wc.T("location.title") }
+func (s *installLocationStep) CanAdvance() bool { return true }
+
+func (s *installLocationStep) Content() fyne.CanvasObject {
+	title := widget.NewLabel(s.Title())
+	title.TextStyle.Bold = true
+
+	group := widget.NewRadioGroup([]string{s.wc.T("location.user"), s.wc.T("location.system")}, func(choice string) {
+		s.system = choice == s.wc.T("location.system")
+		s.wc.ui.installDir = s.installDir()
+	})
+	selected := s.wc.T("location.user")
+	if s.system {
+		selected = s.wc.T("location.system")
+	}
+	group.SetSelected(selected)
+	s.wc.ui.installDir = s.installDir()
+
+	return container.NewVBox(title, group)
+}
+
+func userInstallDir() string {
+	if dir := os.Getenv("XDG_BIN_HOME"); dir != "" {
+		return dir
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".local", "bin")
+}
+
+func (s *installLocationStep) installDir() string {
+	if s.system {
+		return "/usr/local/bin"
+	}
+	return userInstallDir()
+}
+
+// conflictResolution is the user's choice for one PlanConflict, picked from
+// the "Resolve..." dialog.
+type conflictResolution string
+
+const (
+	resolvePreferNewer conflictResolution = "prefer newer"
+	resolvePreferOlder conflictResolution = "prefer older"
+	resolveSkip        conflictResolution = "skip"
+)
+
+// dependencyReviewStep resolves and displays the transitive dependency
+// closure for the requested program before anything is installed. Next is
+// blocked while any reported conflict is unresolved.
+type dependencyReviewStep struct {
+	wc     *WizardController
+	loaded bool
+	plan   *Plan
+	err    error
+
+	resolutions map[int]conflictResolution
+}
+
+func newDependencyReviewStep(wc *WizardController) *dependencyReviewStep {
+	return &dependencyReviewStep{wc: wc}
+}
+
+func (s *dependencyReviewStep) Title() string { return s.wc.T("deps.title") }
+
+func (s *dependencyReviewStep) CanAdvance() bool {
+	if !s.loaded {
+		return false
+	}
+	if s.err != nil {
+		return true
+	}
+	return s.unresolvedConflicts() == 0
+}
+
+func (s *dependencyReviewStep) unresolvedConflicts() int {
+	if s.plan == nil {
+		return 0
+	}
+	unresolved := 0
+	for i := range s.plan.Conflicts {
+		if _, ok := s.resolutions[i]; !ok {
+			unresolved++
+		}
+	}
+	return unresolved
+}
+
+func (s *dependencyReviewStep) OnEnter() {
+	if s.loaded {
+		return
+	}
+
+	go func() {
+		plan, err := ResolvePlan(s.wc.ui.program)
+		s.plan = plan
+		s.err = err
+		s.loaded = true
+		s.wc.render()
+	}()
+}
+
+func (s *dependencyReviewStep) Content() fyne.CanvasObject {
+	title := widget.NewLabel(s.Title())
+	title.TextStyle.Bold = true
+
+	if !s.loaded {
+		return container.NewVBox(title, widget.NewLabel("Resolving dependencies..."))
+	}
+	if s.err != nil {
+		return container.NewVBox(title, widget.NewLabel(fmt.Sprintf("Could not resolve dependencies: %v", s.err)))
+	}
+
+	items := []fyne.CanvasObject{
+		title,
+		widget.NewLabel(s.plan.Summary()),
+		s.wc.ui.createInfoTabsWithPlan(s.plan),
+	}
+
+	if len(s.plan.Conflicts) > 0 {
+		conflictsTitle := widget.NewLabel("Conflicts")
+		conflictsTitle.TextStyle.Bold = true
+		items = append(items, widget.NewSeparator(), conflictsTitle)
+
+		for i, conflict := range s.plan.Conflicts {
+			i, conflict := i, conflict
+
+			if resolution, ok := s.resolutions[i]; ok {
+				items = append(items, widget.NewLabel(fmt.Sprintf("%s — resolved: %s", conflict.Describe(), resolution)))
+				continue
+			}
+
+			label := widget.NewLabel(conflict.Describe())
+			resolveBtn := widget.NewButton("Resolve...", func() { s.showResolveDialog(i, conflict) })
+			items = append(items, container.NewBorder(nil, nil, nil, resolveBtn, label))
+		}
+	}
+
+	return container.NewVBox(items...)
+}
+
+// showResolveDialog lets the user prefer the newer or older side of a
+// conflict, or skip the dependency entirely.
+func (s *dependencyReviewStep) showResolveDialog(index int, conflict PlanConflict) {
+	options := []string{string(resolvePreferNewer), string(resolvePreferOlder), string(resolveSkip)}
+	group := widget.NewRadioGroup(options, nil)
+	group.SetSelected(options[0])
+
+	dialog.NewCustomConfirm(
+		"Resolve conflict", "Apply", s.wc.T("nav.cancel"),
+		container.NewVBox(widget.NewLabel(conflict.Describe()), group),
+		func(confirmed bool) {
+			if !confirmed {
+				return
+			}
+			if s.resolutions == nil {
+				s.resolutions = make(map[int]conflictResolution)
+			}
+			s.resolutions[index] = conflictResolution(group.Selected)
+			s.wc.render()
+		},
+		s.wc.ui.window,
+	).Show()
+}
+
+// installationStep hosts the existing download/progress screen.
+type installationStep struct {
+	wc      *WizardController
+	started bool
+}
+
+func newInstallationStep(wc *WizardController) *installationStep {
+	return &installationStep{wc: wc}
+}
+
+func (s *installationStep) Title() string    { return "Installing" }
+func (s *installationStep) CanAdvance() bool { return s.wc.ui.installFinished }
+
+func (s *installationStep) OnEnter() {
+	if s.started {
+		return
+	}
+	s.started = true
+	s.wc.ui.StartInstallation(func() {
+		s.wc.ui.installFinished = true
+		s.wc.Refresh()
+	})
+}
+
+func (s *installationStep) Content() fyne.CanvasObject {
+	return s.wc.ui.installationContent
+}
+
+// completionStep offers post-install actions.
+type completionStep struct {
+	wc *WizardController
+}
+
+func newCompletionStep(wc *WizardController) *completionStep { return &completionStep{wc: wc} }
+
+func (s *completionStep) Title() string    { return s.wc.T("complete.title") }
+func (s *completionStep) CanAdvance() bool { return true }
+
+func (s *completionStep) Content() fyne.CanvasObject {
+	title := widget.NewLabel(s.Title())
+	title.TextStyle.Bold = true
+
+	launchBtn := widget.NewButton(s.wc.T("complete.launch"), func() {
+		s.wc.ui.LaunchInstalledProgram()
+	})
+	showBtn := widget.NewButton(s.wc.T("complete.showInFiles"), func() {
+		s.wc.ui.ShowInFileManager()
+	})
+
+	return container.NewVBox(title, container.NewHBox(launchBtn, showBtn))
+}